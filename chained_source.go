@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+// ChainedSource tries each underlying source in order, applying Timeout to
+// every attempt, and returns the first one that succeeds. It exists so the
+// tracker keeps working when a preferred source (e.g. GoldAPI) is rate
+// limited, down, or simply not configured.
+type ChainedSource struct {
+	Sources []PriceSource
+	Timeout time.Duration
+}
+
+func (c *ChainedSource) FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error) {
+	var lastErr error
+	for _, src := range c.Sources {
+		attemptCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+		price, err := src.FetchPerGram(attemptCtx, metal, currency)
+		cancel()
+		if err == nil {
+			return price, nil
+		}
+		log.Printf("⚠️  price source failed, trying next: %v", err)
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = errors.New("no price sources configured")
+	}
+	return 0, lastErr
+}
+
+func (c *ChainedSource) Name() string { return "chained:" + sourceNames(c.Sources) }
+
+// AgreementSource queries every underlying source and returns the median
+// price, but only once enough sources agree within MaxDisagreementPct of
+// that median. It guards against a single misbehaving source (stale page,
+// bad scrape) silently driving an alert.
+type AgreementSource struct {
+	Sources            []PriceSource
+	MaxDisagreementPct float64
+}
+
+func (a *AgreementSource) FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error) {
+	prices := make([]float64, 0, len(a.Sources))
+	for _, src := range a.Sources {
+		price, err := src.FetchPerGram(ctx, metal, currency)
+		if err != nil {
+			log.Printf("⚠️  agreement source: one source failed: %v", err)
+			continue
+		}
+		prices = append(prices, price)
+	}
+
+	if len(prices) == 0 {
+		return 0, errors.New("agreement source: no source returned a price")
+	}
+
+	med := median(prices)
+
+	agreeing := make([]float64, 0, len(prices))
+	for _, p := range prices {
+		if pctDiff(p, med) <= a.MaxDisagreementPct {
+			agreeing = append(agreeing, p)
+		} else {
+			log.Printf("⚠️  agreement source: rejecting outlier %.2f (median %.2f)", p, med)
+		}
+	}
+
+	if len(agreeing) == 0 {
+		return 0, errors.New("agreement source: no sources agreed within threshold")
+	}
+
+	return median(agreeing), nil
+}
+
+func (a *AgreementSource) Name() string { return "agreement:" + sourceNames(a.Sources) }
+
+// sourceNames joins the Name() of each source for a ChainedSource's or
+// AgreementSource's own Name(), so PriceEvent.Source reflects what's
+// actually configured rather than a fixed label.
+func sourceNames(sources []PriceSource) string {
+	names := make([]string, len(sources))
+	for i, s := range sources {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func pctDiff(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff / b * 100
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSource is a stub PriceSource for exercising ChainedSource and
+// AgreementSource without a network round trip.
+type fakeSource struct {
+	name  string
+	price float64
+	err   error
+}
+
+func (f *fakeSource) FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.price, nil
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func TestChainedSource_FallsThroughToNextOnFailure(t *testing.T) {
+	c := &ChainedSource{
+		Sources: []PriceSource{
+			&fakeSource{name: "first", err: errors.New("down")},
+			&fakeSource{name: "second", price: 100},
+		},
+		Timeout: time.Second,
+	}
+
+	price, err := c.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price != 100 {
+		t.Fatalf("expected 100, got %v", price)
+	}
+}
+
+func TestChainedSource_AllFail(t *testing.T) {
+	c := &ChainedSource{
+		Sources: []PriceSource{
+			&fakeSource{name: "first", err: errors.New("down")},
+			&fakeSource{name: "second", err: errors.New("also down")},
+		},
+		Timeout: time.Second,
+	}
+
+	_, err := c.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err == nil {
+		t.Fatal("expected an error when every source fails")
+	}
+}
+
+func TestAgreementSource_RejectsOutlier(t *testing.T) {
+	a := &AgreementSource{
+		Sources: []PriceSource{
+			&fakeSource{name: "a", price: 100},
+			&fakeSource{name: "b", price: 101},
+			&fakeSource{name: "c", price: 150},
+		},
+		MaxDisagreementPct: 5,
+	}
+
+	price, err := a.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price != 100.5 {
+		t.Fatalf("expected median of agreeing sources 100.5, got %v", price)
+	}
+}
+
+func TestAgreementSource_NoneAgree(t *testing.T) {
+	a := &AgreementSource{
+		Sources: []PriceSource{
+			&fakeSource{name: "a", price: 100},
+			&fakeSource{name: "b", price: 100},
+			&fakeSource{name: "c", price: 200},
+			&fakeSource{name: "d", price: 200},
+		},
+		MaxDisagreementPct: 5,
+	}
+
+	_, err := a.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err == nil {
+		t.Fatal("expected an error when no sources agree within threshold")
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+var (
+	goldAPIKey       = os.Getenv("GOLD_API_KEY")
+	pushoverAppToken = os.Getenv("PUSHOVER_APP_TOKEN")
+	pushoverUserKey  = os.Getenv("PUSHOVER_USER_KEY")
+
+	// API_USERNAME / API_PASSWORD gate the read-only HTTP API below with
+	// Basic Auth. Leaving either unset disables the API.
+	apiUsername = os.Getenv("API_USERNAME")
+	apiPassword = os.Getenv("API_PASSWORD")
+	apiAddr     = envOr("API_ADDR", ":8080")
+
+	// STALE_PRICE_THRESHOLD_MINUTES is how long the cached Latest snapshot
+	// can go without a successful refresh before checkStaleness warns.
+	staleThreshold = envMinutes("STALE_PRICE_THRESHOLD_MINUTES", 180)
+)
+
+// httpClientTimeout bounds every outbound HTTP call made by a notifier or
+// price source backend, so one unresponsive upstream can only stall its
+// own request instead of hanging indefinitely.
+const httpClientTimeout = 10 * time.Second
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpClientTimeout}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envMinutes(key string, fallback int) time.Duration {
+	minutes, err := strconv.Atoi(envOr(key, ""))
+	if err != nil {
+		minutes = fallback
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ErrRateLimited means the upstream told us to back off, with an optional
+// hint for how long.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
+
+// ErrUpstreamUnavailable means the upstream itself is down (5xx), as
+// opposed to us being throttled or misconfigured.
+type ErrUpstreamUnavailable struct {
+	StatusCode int
+}
+
+func (e *ErrUpstreamUnavailable) Error() string {
+	return fmt.Sprintf("upstream unavailable, status %d", e.StatusCode)
+}
+
+// ErrAuth means our credentials were rejected. Retrying won't help.
+type ErrAuth struct{}
+
+func (e *ErrAuth) Error() string { return "authentication failed" }
+
+// ErrBadPayload means the upstream returned 200 but a body we couldn't
+// parse. Retrying won't help; the response shape itself is wrong.
+type ErrBadPayload struct {
+	Err error
+}
+
+func (e *ErrBadPayload) Error() string { return fmt.Sprintf("bad payload: %v", e.Err) }
+func (e *ErrBadPayload) Unwrap() error { return e.Err }
+
+// ErrTransient is a catch-all for failures worth retrying that don't fit
+// one of the more specific categories above (odd status codes, network
+// blips surfaced as a non-200 response).
+type ErrTransient struct {
+	StatusCode int
+}
+
+func (e *ErrTransient) Error() string {
+	return fmt.Sprintf("transient upstream error, status %d", e.StatusCode)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// PendingNotification is a notification EvaluateRules decided to send,
+// deferred so the caller can dispatch it (with its retries/backoff)
+// without holding whatever lock guards state.
+type PendingNotification struct {
+	RuleID   string
+	Message  Message
+	Notifier Notifier
+
+	// prevTriggered, prevTriggeredAt and prevLastNotifiedAt are the rule's
+	// latch/cooldown bookkeeping from just before EvaluateRules optimistically
+	// committed this notification. If delivery turns out to fail entirely,
+	// ReconcileFailedNotifications restores them so a typo'd token or a
+	// briefly-down notifier doesn't silently eat the rule's cooldown window.
+	prevTriggered      bool
+	prevTriggeredAt    time.Time
+	prevLastNotifiedAt time.Time
+}
+
+// EvaluateRules checks every rule against snap (and dayBefore, for
+// pct_drop_24h), updating state.Rules and returning the notifications
+// that should fire. now is threaded in explicitly so the
+// cooldown/hysteresis logic is unit testable against synthetic price
+// series. notifiers is the tag -> backend registry used to resolve a
+// rule's NotifierTags; defaultNotifier is used for rules that don't set
+// any tags.
+//
+// EvaluateRules only updates bookkeeping (Triggered, TriggeredAt,
+// LastNotifiedAt, LastPrice) and never calls a Notifier itself, so it's
+// safe to run under a lock; SendNotifications does the actual (slow,
+// retrying) delivery and should be called after releasing it. That
+// bookkeeping is committed optimistically, before delivery is even
+// attempted, so the caller must feed SendNotifications' result back into
+// ReconcileFailedNotifications (under the lock again) to undo it for any
+// rule that was never actually delivered.
+func EvaluateRules(rules []Rule, snap, dayBefore Snapshot, state *State, notifiers map[string]Notifier, defaultNotifier Notifier, now time.Time) []PendingNotification {
+	var pending []PendingNotification
+
+	for _, r := range rules {
+		rs := state.Rules[r.ID]
+		price := priceFor(snap, r)
+
+		fired := ruleFires(r, price, dayBefore, rs)
+
+		if fired {
+			if !rs.LastNotifiedAt.IsZero() && now.Sub(rs.LastNotifiedAt) < r.Cooldown {
+				rs.LastPrice = price
+				state.Rules[r.ID] = rs
+				continue
+			}
+
+			msg := Message{
+				Title: "🚨 Price Alert",
+				Body: fmt.Sprintf(
+					"rule %s: %s %s %s %.2f (now %.2f)",
+					r.ID, r.Metal, r.Karat, r.Operator, r.Threshold, price,
+				),
+				Priority: 1,
+			}
+			pending = append(pending, PendingNotification{
+				RuleID:             r.ID,
+				Message:            msg,
+				Notifier:           notifierForRule(r, notifiers, defaultNotifier),
+				prevTriggered:      rs.Triggered,
+				prevTriggeredAt:    rs.TriggeredAt,
+				prevLastNotifiedAt: rs.LastNotifiedAt,
+			})
+
+			rs.Triggered = true
+			rs.TriggeredAt = now
+			rs.LastNotifiedAt = now
+		} else if rs.Triggered && ruleClearsReset(r, price) {
+			log.Printf("🔄 resetting rule %s\n", r.ID)
+			rs.Triggered = false
+		}
+
+		rs.LastPrice = price
+		state.Rules[r.ID] = rs
+	}
+
+	return pending
+}
+
+// SendNotifications dispatches every PendingNotification returned by
+// EvaluateRules. It's split out from EvaluateRules so the caller can
+// release its state lock first: each Notifier.Notify may retry with
+// backoff, and doing that while holding the lock would block everything
+// else that reads state (the /metal API, other evaluator ticks) behind
+// one slow or unresponsive backend.
+//
+// It returns the subset of pending that failed entirely (p.Notifier is
+// typically a MultiNotifier, which only reports an error once every
+// backend it wraps has failed), for the caller to feed into
+// ReconcileFailedNotifications.
+func SendNotifications(ctx context.Context, pending []PendingNotification) []PendingNotification {
+	var failed []PendingNotification
+	for _, p := range pending {
+		if err := p.Notifier.Notify(ctx, p.Message); err != nil {
+			log.Printf("❌ notify error for rule %s: %v\n", p.RuleID, err)
+			failed = append(failed, p)
+		}
+	}
+	return failed
+}
+
+// ReconcileFailedNotifications undoes the optimistic latch/cooldown
+// bookkeeping EvaluateRules committed for any rule in failed, restoring
+// Triggered/TriggeredAt/LastNotifiedAt to what they were before that
+// notification was decided. Call this under the same lock as
+// EvaluateRules, after SendNotifications returns. Without it, a rule
+// whose every notifier backend failed would still be latched as
+// "notified" and sit out its full cooldown while the price keeps moving.
+func ReconcileFailedNotifications(state *State, failed []PendingNotification) {
+	for _, p := range failed {
+		rs := state.Rules[p.RuleID]
+		rs.Triggered = p.prevTriggered
+		rs.TriggeredAt = p.prevTriggeredAt
+		rs.LastNotifiedAt = p.prevLastNotifiedAt
+		state.Rules[p.RuleID] = rs
+	}
+}
+
+// notifierForRule resolves which backends a Rule notifies through: the
+// subset of notifiers named in r.NotifierTags, or defaultNotifier when no
+// tags are set (or none of them match a configured backend).
+func notifierForRule(r Rule, notifiers map[string]Notifier, defaultNotifier Notifier) Notifier {
+	if len(r.NotifierTags) == 0 {
+		return defaultNotifier
+	}
+
+	var tagged []Notifier
+	for _, tag := range r.NotifierTags {
+		if n, ok := notifiers[tag]; ok {
+			tagged = append(tagged, n)
+		}
+	}
+	if len(tagged) == 0 {
+		return defaultNotifier
+	}
+	return &MultiNotifier{Backends: tagged, Retry: defaultRetryConfig}
+}
+
+// ruleFires reports whether r's condition holds for the current price,
+// independent of cooldown/hysteresis bookkeeping (handled by the caller).
+func ruleFires(r Rule, price float64, dayBefore Snapshot, rs RuleState) bool {
+	switch r.Operator {
+	case OpLTE:
+		return price <= r.Threshold
+	case OpGTE:
+		return price >= r.Threshold
+	case OpCrossDown:
+		return price <= r.Threshold && rs.LastPrice > r.Threshold
+	case OpPctDrop24h:
+		prev := priceFor(dayBefore, r)
+		if prev <= 0 {
+			return false
+		}
+		drop := (prev - price) / prev * 100
+		return drop >= r.Threshold
+	default:
+		return false
+	}
+}
+
+// ruleClearsReset reports whether price has recovered far enough past
+// r.Threshold (by r.ResetBuffer) to re-arm a latched rule. It mirrors
+// ruleFires' direction: a rule that fires on the price falling resets on
+// the price rising back out, and vice versa. pct_drop_24h has no
+// meaningful hysteresis of its own — each evaluation is against a fresh
+// 24h window, so it re-arms as soon as it stops firing.
+func ruleClearsReset(r Rule, price float64) bool {
+	switch r.Operator {
+	case OpGTE:
+		return price < r.Threshold-r.ResetBuffer
+	case OpPctDrop24h:
+		return true
+	default: // OpLTE, OpCrossDown
+		return price > r.Threshold+r.ResetBuffer
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeNotifier records every message it receives instead of sending
+// anything over the network.
+type fakeNotifier struct {
+	sent []Message
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, msg Message) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func snapAt(gold22K float64, t time.Time) Snapshot {
+	return Snapshot{Gold22K: gold22K, Time: t}
+}
+
+// evaluateAndNotify is the test-only equivalent of what consumeEvents
+// does in production: run EvaluateRules, dispatch whatever it decided to
+// send, then reconcile any rule that failed to deliver entirely.
+func evaluateAndNotify(rules []Rule, snap, dayBefore Snapshot, state *State, notifiers map[string]Notifier, defaultNotifier Notifier, now time.Time) {
+	pending := EvaluateRules(rules, snap, dayBefore, state, notifiers, defaultNotifier, now)
+	failed := SendNotifications(context.Background(), pending)
+	ReconcileFailedNotifications(state, failed)
+}
+
+// failingNotifier always fails delivery, simulating every backend behind
+// a rule being down (e.g. a typo'd token).
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(ctx context.Context, msg Message) error {
+	return errors.New("notify: simulated total failure")
+}
+
+func TestEvaluateRules_TriggersOnce(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	// First tick below threshold: should fire.
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, nil, notifier, now)
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.sent))
+	}
+	if !state.Rules["r1"].Triggered {
+		t.Fatal("expected rule to be latched as triggered")
+	}
+
+	// Still below threshold, within cooldown: should not fire again.
+	evaluateAndNotify([]Rule{rule}, snapAt(94, now.Add(time.Minute)), Snapshot{}, state, nil, notifier, now.Add(time.Minute))
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected no retrigger within cooldown, got %d notifications", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_ResetAfterBuffer(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, nil, notifier, now)
+	if !state.Rules["r1"].Triggered {
+		t.Fatal("expected rule to be latched as triggered")
+	}
+
+	// Price recovers past threshold + reset buffer: rule should re-arm.
+	evaluateAndNotify([]Rule{rule}, snapAt(115, now.Add(time.Hour)), Snapshot{}, state, nil, notifier, now.Add(time.Hour))
+	if state.Rules["r1"].Triggered {
+		t.Fatal("expected rule to reset once price cleared reset buffer")
+	}
+
+	// Drops below threshold again after the cooldown window: should fire again.
+	evaluateAndNotify([]Rule{rule}, snapAt(90, now.Add(3*time.Hour)), Snapshot{}, state, nil, notifier, now.Add(3*time.Hour))
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected a second notification after reset, got %d", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_NoRetriggerWithinCooldownAcrossResetBoundary(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: 2 * time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, nil, notifier, now)
+
+	// Dips again 30 minutes later, well inside the 2h cooldown.
+	evaluateAndNotify([]Rule{rule}, snapAt(92, now.Add(30*time.Minute)), Snapshot{}, state, nil, notifier, now.Add(30*time.Minute))
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected cooldown to suppress retrigger, got %d notifications", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_NoRetriggerWithinCooldownAfterReset(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: 2 * time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	// Fires at t=0.
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, nil, notifier, now)
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.sent))
+	}
+
+	// Recovers past the reset buffer a minute later: un-latches.
+	evaluateAndNotify([]Rule{rule}, snapAt(115, now.Add(time.Minute)), Snapshot{}, state, nil, notifier, now.Add(time.Minute))
+	if state.Rules["r1"].Triggered {
+		t.Fatal("expected rule to reset once price cleared the reset buffer")
+	}
+
+	// Dips below threshold again at t=2min, well inside the 2h cooldown:
+	// resetting must not bypass the cooldown guard.
+	evaluateAndNotify([]Rule{rule}, snapAt(90, now.Add(2*time.Minute)), Snapshot{}, state, nil, notifier, now.Add(2*time.Minute))
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected cooldown to suppress retrigger after reset, got %d notifications", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_MultipleRulesFireIndependently(t *testing.T) {
+	goldRule := Rule{ID: "gold", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour}
+	silverRule := Rule{ID: "silver", Metal: MetalSilver, Operator: OpLTE, Threshold: 90, ResetBuffer: 5, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	snap := Snapshot{Gold22K: 95, Silver: 85, Time: now}
+	evaluateAndNotify([]Rule{goldRule, silverRule}, snap, Snapshot{}, state, nil, notifier, now)
+
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected both rules to fire, got %d notifications", len(notifier.sent))
+	}
+	if !state.Rules["gold"].Triggered || !state.Rules["silver"].Triggered {
+		t.Fatal("expected both rules latched as triggered")
+	}
+}
+
+func TestEvaluateRules_PctDrop24h(t *testing.T) {
+	rule := Rule{ID: "drop", Metal: MetalSilver, Operator: OpPctDrop24h, Threshold: 2, ResetBuffer: 0, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	dayBefore := Snapshot{Silver: 100}
+	today := Snapshot{Silver: 97} // 3% drop, above the 2% threshold
+
+	evaluateAndNotify([]Rule{rule}, today, dayBefore, state, nil, notifier, now)
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected pct_drop_24h rule to fire, got %d notifications", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_GTEResetsAndRetriggers(t *testing.T) {
+	rule := Rule{ID: "silver-crosses-above", Metal: MetalSilver, Operator: OpGTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	notifier := &fakeNotifier{}
+	now := time.Now()
+
+	// Crosses above threshold: should fire.
+	evaluateAndNotify([]Rule{rule}, Snapshot{Silver: 105, Time: now}, Snapshot{}, state, nil, notifier, now)
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.sent))
+	}
+	if !state.Rules["silver-crosses-above"].Triggered {
+		t.Fatal("expected rule to be latched as triggered")
+	}
+
+	// Drops back down but not past the reset buffer: should stay latched.
+	evaluateAndNotify([]Rule{rule}, Snapshot{Silver: 95, Time: now.Add(time.Hour)}, Snapshot{}, state, nil, notifier, now.Add(time.Hour))
+	if !state.Rules["silver-crosses-above"].Triggered {
+		t.Fatal("expected rule to remain latched above the reset buffer")
+	}
+
+	// Drops past threshold - buffer: should re-arm.
+	evaluateAndNotify([]Rule{rule}, Snapshot{Silver: 85, Time: now.Add(2 * time.Hour)}, Snapshot{}, state, nil, notifier, now.Add(2*time.Hour))
+	if state.Rules["silver-crosses-above"].Triggered {
+		t.Fatal("expected rule to reset once price cleared the reset buffer")
+	}
+
+	// Crosses above again after the cooldown and reset: should fire again.
+	evaluateAndNotify([]Rule{rule}, Snapshot{Silver: 110, Time: now.Add(3 * time.Hour)}, Snapshot{}, state, nil, notifier, now.Add(3*time.Hour))
+	if len(notifier.sent) != 2 {
+		t.Fatalf("expected a second notification after reset, got %d", len(notifier.sent))
+	}
+}
+
+func TestEvaluateRules_NotifierTagsRouteToTaggedBackendOnly(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour, NotifierTags: []string{"telegram"}}
+	state := &State{Rules: map[string]RuleState{}}
+	tagged := &fakeNotifier{}
+	untagged := &fakeNotifier{}
+	defaultNotifier := &fakeNotifier{}
+	notifiers := map[string]Notifier{"telegram": tagged, "webhook": untagged}
+	now := time.Now()
+
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, notifiers, defaultNotifier, now)
+
+	if len(tagged.sent) != 1 {
+		t.Fatalf("expected 1 notification on the tagged backend, got %d", len(tagged.sent))
+	}
+	if len(untagged.sent) != 0 {
+		t.Fatalf("expected no notification on the untagged backend, got %d", len(untagged.sent))
+	}
+	if len(defaultNotifier.sent) != 0 {
+		t.Fatalf("expected no notification on the default notifier, got %d", len(defaultNotifier.sent))
+	}
+}
+
+func TestEvaluateRules_UnmatchedNotifierTagsFallBackToDefault(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour, NotifierTags: []string{"nonexistent"}}
+	state := &State{Rules: map[string]RuleState{}}
+	defaultNotifier := &fakeNotifier{}
+	notifiers := map[string]Notifier{"telegram": &fakeNotifier{}}
+	now := time.Now()
+
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, notifiers, defaultNotifier, now)
+
+	if len(defaultNotifier.sent) != 1 {
+		t.Fatalf("expected the default notifier to receive the notification, got %d", len(defaultNotifier.sent))
+	}
+}
+
+func TestEvaluateRules_RetriesAfterTotalNotifyFailure(t *testing.T) {
+	rule := Rule{ID: "r1", Metal: MetalGold, Karat: "22K", Operator: OpLTE, Threshold: 100, ResetBuffer: 10, Cooldown: time.Hour}
+	state := &State{Rules: map[string]RuleState{}}
+	now := time.Now()
+
+	// First tick below threshold: fires, but every notifier backend fails.
+	evaluateAndNotify([]Rule{rule}, snapAt(95, now), Snapshot{}, state, nil, failingNotifier{}, now)
+	if state.Rules["r1"].Triggered {
+		t.Fatal("expected the latch to be rolled back after total notify failure")
+	}
+	if !state.Rules["r1"].LastNotifiedAt.IsZero() {
+		t.Fatal("expected LastNotifiedAt to be rolled back after total notify failure")
+	}
+
+	// Still below threshold moments later: since nothing was ever
+	// delivered, the cooldown must not suppress a retry.
+	notifier := &fakeNotifier{}
+	evaluateAndNotify([]Rule{rule}, snapAt(94, now.Add(time.Minute)), Snapshot{}, state, nil, notifier, now.Add(time.Minute))
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected a retry after the prior delivery failed entirely, got %d notifications", len(notifier.sent))
+	}
+	if !state.Rules["r1"].Triggered {
+		t.Fatal("expected the rule to be latched once delivery actually succeeds")
+	}
+}
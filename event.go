@@ -0,0 +1,48 @@
+package main
+
+import "time"
+
+// PriceEvent is the tracker's one internal currency: both the polling
+// path and the follower path (Kafka/Redis) produce these into the same
+// channel, and the evaluator only ever looks at PriceEvents, never at a
+// PriceSource directly. Karat is only meaningful for MetalGold; empty
+// means 24K. On the wire (Kafka/Redis payload) this is plain JSON.
+type PriceEvent struct {
+	Metal        Metal     `json:"metal"`
+	Karat        string    `json:"karat,omitempty"`
+	PricePerGram float64   `json:"price_per_gram"`
+	Currency     string    `json:"currency"`
+	Source       string    `json:"source"`
+	Ts           time.Time `json:"ts"`
+}
+
+// snapshotEventsFor expands a polled Snapshot into the discrete
+// PriceEvents it represents, so the polling path can feed the same event
+// channel a follower subscription would.
+func snapshotEventsFor(snap Snapshot, source string) []PriceEvent {
+	return []PriceEvent{
+		{Metal: MetalGold, Karat: "24K", PricePerGram: snap.Gold24K, Currency: currency, Source: source, Ts: snap.Time},
+		{Metal: MetalSilver, PricePerGram: snap.Silver, Currency: currency, Source: source, Ts: snap.Time},
+		{Metal: MetalPlatinum, PricePerGram: snap.Platinum, Currency: currency, Source: source, Ts: snap.Time},
+	}
+}
+
+// applyEvent folds a PriceEvent into the running Snapshot, deriving
+// Gold22K whenever 24K gold updates.
+func applyEvent(snap *Snapshot, ev PriceEvent) {
+	snap.Time = ev.Ts
+
+	switch ev.Metal {
+	case MetalGold:
+		if ev.Karat == "22K" {
+			snap.Gold22K = ev.PricePerGram
+		} else {
+			snap.Gold24K = ev.PricePerGram
+			snap.Gold22K = convertTo22K(ev.PricePerGram)
+		}
+	case MetalSilver:
+		snap.Silver = ev.PricePerGram
+	case MetalPlatinum:
+		snap.Platinum = ev.PricePerGram
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import "context"
+
+// FeedSource is an upstream broker subscription for follower mode: it
+// publishes PriceEvents onto out until ctx is canceled or the
+// subscription errors.
+type FeedSource interface {
+	Subscribe(ctx context.Context, out chan<- PriceEvent) error
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildFeedSource assembles the follower-mode FeedSource from env:
+//
+//	FEED_BROKER       "kafka" or "redis" (default "kafka")
+//	KAFKA_BROKERS     comma-separated list, e.g. "localhost:9092"
+//	KAFKA_TOPIC       topic carrying PriceEvent JSON (default "gold-tracker.prices")
+//	KAFKA_GROUP_ID    consumer group id (default "gold-tracker")
+//	REDIS_ADDR        e.g. "localhost:6379"
+//	REDIS_CHANNEL     pub/sub channel (default "gold-tracker.prices")
+func buildFeedSource() (FeedSource, error) {
+	switch envOr("FEED_BROKER", "kafka") {
+	case "kafka":
+		return &KafkaFeedSource{
+			Brokers: strings.Split(envOr("KAFKA_BROKERS", "localhost:9092"), ","),
+			Topic:   envOr("KAFKA_TOPIC", "gold-tracker.prices"),
+			GroupID: envOr("KAFKA_GROUP_ID", "gold-tracker"),
+		}, nil
+	case "redis":
+		return &RedisFeedSource{
+			Addr:    envOr("REDIS_ADDR", "localhost:6379"),
+			Channel: envOr("REDIS_CHANNEL", "gold-tracker.prices"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown FEED_BROKER %q", envOr("FEED_BROKER", ""))
+	}
+}
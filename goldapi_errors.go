@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// classifyGoldAPIError turns a non-200 GoldAPI response into one of the
+// typed errors above, reading Retry-After / x-ratelimit-* where GoldAPI
+// provides them.
+func classifyGoldAPIError(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return &ErrAuth{}
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &ErrRateLimited{RetryAfter: retryAfter(resp)}
+	case resp.StatusCode >= 500:
+		return &ErrUpstreamUnavailable{StatusCode: resp.StatusCode}
+	default:
+		return &ErrTransient{StatusCode: resp.StatusCode}
+	}
+}
+
+// retryAfter reads the Retry-After header (seconds, per RFC 7231) and
+// falls back to x-ratelimit-reset (also seconds) if present, defaulting
+// to 30s if neither is set or parseable.
+func retryAfter(resp *http.Response) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("x-ratelimit-reset")); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 30 * time.Second
+}
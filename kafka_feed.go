@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaFeedSource consumes PriceEvents (one JSON object per message) from
+// a Kafka topic as part of a consumer group, so multiple tracker
+// replicas can share the same upstream feed without duplicating work.
+type KafkaFeedSource struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+}
+
+func (k *KafkaFeedSource) Subscribe(ctx context.Context, out chan<- PriceEvent) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: k.Brokers,
+		Topic:   k.Topic,
+		GroupID: k.GroupID,
+	})
+	defer reader.Close()
+
+	for {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		var ev PriceEvent
+		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+			log.Printf("⚠️  kafka feed: dropping malformed message: %v", err)
+			continue
+		}
+
+		select {
+		case out <- ev:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
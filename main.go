@@ -1,150 +1,137 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"flag"
 	"log"
-	"net/http"
-	"net/url"
-	"os"
+	"sync"
+	"time"
 )
 
 const (
-	stateFile = "state.json"
-
-	// 🔔 ALERT CONFIG (22K gold price)
-	targetGoldPrice22K = 12700.0
-	resetBuffer        = 200.0
-)
-
-var (
-	goldAPIKey        = os.Getenv("GOLD_API_KEY")
-	pushoverAppToken  = os.Getenv("PUSHOVER_APP_TOKEN")
-	pushoverUserKey   = os.Getenv("PUSHOVER_USER_KEY")
+	stateFile    = "state.json"
+	pollInterval = 30 * time.Minute
 )
 
-type State struct {
-	AlertTriggered bool `json:"alert_triggered"`
-}
-
-type GoldAPIResponse struct {
-	PricePerOunce float64 `json:"price"`
-}
-
 func main() {
-	log.Println("🚀 Gold Alert Job Started")
-	run()
-}
+	follower := flag.Bool("follower", false, "subscribe to a Kafka/Redis price feed instead of polling GoldAPI")
+	flag.Parse()
 
-func run() {
+	var mu sync.Mutex
 	state := loadState()
 
-	price24K, err := fetchGoldPricePerGram()
-	if err != nil {
-		log.Println("❌ Price fetch failed:", err)
-		return
+	getState := func() State {
+		mu.Lock()
+		defer mu.Unlock()
+		return state
 	}
 
-	price22K := convertTo22K(price24K)
+	notifierRegistry := buildNotifierRegistry()
+	notifier := buildNotifier(notifierRegistry)
 
-	log.Printf("💰 Gold 24K: ₹%.2f / g\n", price24K)
-	log.Printf("💰 Gold 22K: ₹%.2f / g\n", price22K)
-
-	// 🔔 ALERT
-	if price22K <= targetGoldPrice22K && !state.AlertTriggered {
-		msg := fmt.Sprintf(
-			"22K Gold hit ₹%.2f / gram\nTarget: ₹%.2f",
-			price22K,
-			targetGoldPrice22K,
-		)
+	rules, err := loadRules()
+	if err != nil {
+		log.Fatalln("❌ loading rules:", err)
+	}
 
-		if err := sendPushover(msg); err != nil {
-			log.Println("❌ Pushover error:", err)
-			return
-		}
+	events := make(chan []PriceEvent, 64)
+	ctx := context.Background()
 
-		log.Println("✅ Pushover alert sent")
-		state.AlertTriggered = true
-		saveState(state)
-	}
+	go consumeEvents(ctx, events, &mu, &state, rules, notifierRegistry, notifier)
 
-	// 🔄 RESET LOGIC
-	if price22K > targetGoldPrice22K+resetBuffer && state.AlertTriggered {
-		log.Println("🔄 Resetting alert state")
-		state.AlertTriggered = false
-		saveState(state)
-	}
-}
+	srv := newServer(getState)
+	go startServer(srv)
 
-func fetchGoldPricePerGram() (float64, error) {
-	req, _ := http.NewRequest(
-		"GET",
-		"https://www.goldapi.io/api/XAU/INR",
-		nil,
-	)
-	req.Header.Set("x-access-token", goldAPIKey)
-	req.Header.Set("Content-Type", "application/json")
+	if *follower {
+		log.Println("🚀 Gold Tracker Started (follower mode)")
+		feed, err := buildFeedSource()
+		if err != nil {
+			log.Fatalln("❌ building feed source:", err)
+		}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, err
+		// Each follower message is its own independent update, unlike a
+		// poll tick's three metals fetched together, so it's forwarded as
+		// a batch of one rather than coalesced with anything else.
+		feedEvents := make(chan PriceEvent, 64)
+		go func() {
+			for ev := range feedEvents {
+				events <- []PriceEvent{ev}
+			}
+		}()
+
+		if err := feed.Subscribe(ctx, feedEvents); err != nil {
+			log.Fatalln("❌ feed subscription ended:", err)
+		}
+		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return 0, fmt.Errorf("goldapi status %d", resp.StatusCode)
-	}
+	log.Println("🚀 Gold Tracker Started (polling mode)")
+	src := buildPriceSource()
+	pollOnce(ctx, src, events, &mu, &state, notifier)
 
-	var data GoldAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return 0, err
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		pollOnce(ctx, src, events, &mu, &state, notifier)
 	}
-
-	const ounceToGram = 31.1035
-	return data.PricePerOunce / ounceToGram, nil
 }
 
-func sendPushover(message string) error {
-	form := url.Values{}
-	form.Add("token", pushoverAppToken)
-	form.Add("user", pushoverUserKey)
-	form.Add("title", "🚨 Gold Price Alert")
-	form.Add("message", message)
-	form.Add("priority", "1")
-
-	resp, err := http.PostForm(
-		"https://api.pushover.net/1/messages.json",
-		form,
-	)
+// pollOnce fetches one Snapshot from src and feeds it into events as the
+// batch of PriceEvents it represents, so the polling path and the
+// follower path converge on the same consumer. The three metals are
+// pushed as a single batch rather than one event each, so consumeEvents
+// folds them into one updated Snapshot and evaluates rules once per poll
+// instead of once per metal. On total fetch failure it falls back to
+// warning about a stale cached snapshot instead of quietly skipping the
+// tick.
+func pollOnce(ctx context.Context, src PriceSource, events chan<- []PriceEvent, mu *sync.Mutex, state *State, notifier Notifier) {
+	snap, err := fetchSnapshot(ctx, src)
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+		log.Println("❌ Price fetch failed:", err)
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("pushover returned %d", resp.StatusCode)
+		mu.Lock()
+		staleMsg := checkStaleness(state, time.Now(), staleThreshold)
+		saveState(*state)
+		mu.Unlock()
+
+		sendStaleWarning(ctx, notifier, staleMsg)
+		return
 	}
 
-	return nil
-}
+	log.Printf("💰 Gold 24K: ₹%.2f / g\n", snap.Gold24K)
+	log.Printf("💰 Gold 22K: ₹%.2f / g\n", snap.Gold22K)
 
-func convertTo22K(price24K float64) float64 {
-	const purityFactor = 0.916
-	const gstFactor = 1.03
-	return price24K * purityFactor * gstFactor
+	events <- snapshotEventsFor(snap, src.Name())
 }
 
-func loadState() State {
-	data, err := os.ReadFile(stateFile)
-	if err != nil {
-		return State{}
+// consumeEvents is the tracker's single evaluator: every batch of
+// PriceEvents, whether it came from one poll tick or a single follower
+// message, is folded into the running snapshot together and then checked
+// against the rule set once. Only the snapshot/rule bookkeeping runs
+// under mu; the actual notification delivery (which retries with backoff
+// per backend) happens after mu is released, so one slow or unresponsive
+// notifier can't wedge the /metal API or stall the rest of the evaluator.
+// Afterwards mu is briefly reacquired to reconcile any rule whose
+// notification failed entirely, so a dead notifier doesn't silently burn
+// the rule's cooldown window without ever having delivered anything.
+func consumeEvents(ctx context.Context, events <-chan []PriceEvent, mu *sync.Mutex, state *State, rules []Rule, notifiers map[string]Notifier, defaultNotifier Notifier) {
+	for batch := range events {
+		mu.Lock()
+		next := state.Latest
+		for _, ev := range batch {
+			applyEvent(&next, ev)
+		}
+		state.rollIfNewDay(next)
+		pending := EvaluateRules(rules, state.Latest, state.DayBefore, state, notifiers, defaultNotifier, time.Now())
+		saveState(*state)
+		mu.Unlock()
+
+		failed := SendNotifications(ctx, pending)
+		if len(failed) > 0 {
+			mu.Lock()
+			ReconcileFailedNotifications(state, failed)
+			saveState(*state)
+			mu.Unlock()
+		}
 	}
-	var s State
-	_ = json.Unmarshal(data, &s)
-	return s
-}
-
-func saveState(s State) {
-	data, _ := json.MarshalIndent(s, "", "  ")
-	_ = os.WriteFile(stateFile, data, 0644)
 }
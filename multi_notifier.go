@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// MultiNotifier fans a Message out to every backend. Each backend is
+// retried independently with exponential backoff; one backend failing
+// (or being misconfigured) never blocks the others, and Notify only
+// reports an error when every backend failed to deliver.
+type MultiNotifier struct {
+	Backends []Notifier
+	Retry    retryConfig
+}
+
+func (m *MultiNotifier) Notify(ctx context.Context, msg Message) error {
+	var lastErr error
+	delivered := 0
+
+	for _, backend := range m.Backends {
+		backend := backend
+		err := withRetry(ctx, m.Retry, func() error {
+			return backend.Notify(ctx, msg)
+		})
+		if err != nil {
+			log.Printf("❌ notifier backend failed after retries: %v", err)
+			lastErr = err
+			continue
+		}
+		log.Println("✅ notification delivered")
+		delivered++
+	}
+
+	if delivered > 0 {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return errors.New("no notifier backends configured")
+}
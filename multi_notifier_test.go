@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubNotifier struct {
+	err error
+}
+
+func (s *stubNotifier) Notify(ctx context.Context, msg Message) error {
+	return s.err
+}
+
+func TestMultiNotifier_PartialFailureIsNotAnError(t *testing.T) {
+	m := &MultiNotifier{
+		Backends: []Notifier{
+			&stubNotifier{err: errors.New("telegram misconfigured")},
+			&stubNotifier{},
+		},
+		Retry: retryConfig{MaxAttempts: 1},
+	}
+
+	if err := m.Notify(context.Background(), Message{}); err != nil {
+		t.Fatalf("expected no error when at least one backend delivers, got %v", err)
+	}
+}
+
+func TestMultiNotifier_AllBackendsFailIsAnError(t *testing.T) {
+	m := &MultiNotifier{
+		Backends: []Notifier{
+			&stubNotifier{err: errors.New("telegram misconfigured")},
+			&stubNotifier{err: errors.New("webhook down")},
+		},
+		Retry: retryConfig{MaxAttempts: 1},
+	}
+
+	if err := m.Notify(context.Background(), Message{}); err == nil {
+		t.Fatal("expected an error when every backend fails")
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import "context"
+
+// Message is a single alert to be delivered. Priority is notifier-specific
+// (e.g. Pushover's -2..2 scale) but a notifier without a priority concept
+// is free to ignore it.
+type Message struct {
+	Title    string
+	Body     string
+	Priority int
+}
+
+// Notifier delivers a Message to one backend (Pushover, Telegram, a
+// webhook, ...). Implementations should not retry internally; retries are
+// applied uniformly by withRetry in MultiNotifier.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+)
+
+// buildNotifierRegistry assembles each backend named in NOTIFIERS,
+// keyed by its own name (e.g. "pushover", "telegram"), from env:
+//
+//	NOTIFIERS          comma-separated list, e.g. "pushover,telegram,webhook"
+//	PUSHOVER_APP_TOKEN / PUSHOVER_USER_KEY
+//	TELEGRAM_BOT_TOKEN / TELEGRAM_CHAT_ID
+//	WEBHOOK_URL
+//	NTFY_SERVER_URL (default https://ntfy.sh) / NTFY_TOPIC
+//	SMTP_HOST / SMTP_PORT / SMTP_USERNAME / SMTP_PASSWORD / SMTP_FROM / SMTP_TO
+//
+// The registry is keyed this way so a Rule's notifier_tags can route to a
+// subset of backends instead of always broadcasting to all of them. An
+// unknown or unconfigured name is skipped rather than failing startup.
+func buildNotifierRegistry() map[string]Notifier {
+	names := strings.Split(envOr("NOTIFIERS", "pushover"), ",")
+
+	registry := map[string]Notifier{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if backend := namedNotifier(name); backend != nil {
+			registry[name] = backend
+		}
+	}
+	return registry
+}
+
+// buildNotifier fans out to every backend in registry. It's the default
+// notifier for rules that don't set notifier_tags.
+func buildNotifier(registry map[string]Notifier) Notifier {
+	backends := make([]Notifier, 0, len(registry))
+	for _, backend := range registry {
+		backends = append(backends, backend)
+	}
+	return &MultiNotifier{Backends: backends, Retry: defaultRetryConfig}
+}
+
+func namedNotifier(name string) Notifier {
+	switch name {
+	case "pushover":
+		return &PushoverNotifier{
+			AppToken: pushoverAppToken,
+			UserKey:  pushoverUserKey,
+			Client:   newHTTPClient(),
+		}
+	case "telegram":
+		return &TelegramNotifier{
+			BotToken: envOr("TELEGRAM_BOT_TOKEN", ""),
+			ChatID:   envOr("TELEGRAM_CHAT_ID", ""),
+			Client:   newHTTPClient(),
+		}
+	case "webhook":
+		return &WebhookNotifier{
+			URL:    envOr("WEBHOOK_URL", ""),
+			Client: newHTTPClient(),
+		}
+	case "ntfy":
+		return &NtfyNotifier{
+			ServerURL: envOr("NTFY_SERVER_URL", "https://ntfy.sh"),
+			Topic:     envOr("NTFY_TOPIC", ""),
+			Client:    newHTTPClient(),
+		}
+	case "smtp":
+		return &SMTPNotifier{
+			Host:     envOr("SMTP_HOST", ""),
+			Port:     envOr("SMTP_PORT", "587"),
+			Username: envOr("SMTP_USERNAME", ""),
+			Password: envOr("SMTP_PASSWORD", ""),
+			From:     envOr("SMTP_FROM", ""),
+			To:       envOr("SMTP_TO", ""),
+		}
+	default:
+		return nil
+	}
+}
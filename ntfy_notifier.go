@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NtfyNotifier publishes to a ntfy.sh (or self-hosted ntfy) topic.
+type NtfyNotifier struct {
+	ServerURL string // e.g. "https://ntfy.sh"
+	Topic     string
+	Client    *http.Client
+}
+
+func (n *NtfyNotifier) Notify(ctx context.Context, msg Message) error {
+	url := strings.TrimRight(n.ServerURL, "/") + "/" + n.Topic
+	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(msg.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", msg.Title)
+	req.Header.Set("Priority", strconv.Itoa(ntfyPriority(msg.Priority)))
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("ntfy returned %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ntfyPriority maps our Pushover-style -2..2 priority onto ntfy's 1..5
+// scale, clamping out-of-range values to the nearest valid end.
+func ntfyPriority(p int) int {
+	mapped := p + 3
+	if mapped < 1 {
+		return 1
+	}
+	if mapped > 5 {
+		return 5
+	}
+	return mapped
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PushoverNotifier delivers via the Pushover messages API.
+type PushoverNotifier struct {
+	AppToken string
+	UserKey  string
+	Client   *http.Client
+}
+
+func (p *PushoverNotifier) Notify(ctx context.Context, msg Message) error {
+	form := url.Values{}
+	form.Add("token", p.AppToken)
+	form.Add("user", p.UserKey)
+	form.Add("title", msg.Title)
+	form.Add("message", msg.Body)
+	form.Add("priority", fmt.Sprintf("%d", msg.Priority))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.pushover.net/1/messages.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("pushover returned %d", resp.StatusCode)
+	}
+
+	return nil
+}
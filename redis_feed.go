@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisFeedSource consumes PriceEvents from a Redis pub/sub channel.
+// Unlike Kafka there's no consumer-group replay; it's meant for simpler,
+// single-fetcher deployments fanning out to several alert consumers.
+type RedisFeedSource struct {
+	Addr    string
+	Channel string
+}
+
+func (r *RedisFeedSource) Subscribe(ctx context.Context, out chan<- PriceEvent) error {
+	client := redis.NewClient(&redis.Options{Addr: r.Addr})
+	defer client.Close()
+
+	sub := client.Subscribe(ctx, r.Channel)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			var ev PriceEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+				log.Printf("⚠️  redis feed: dropping malformed message: %v", err)
+				continue
+			}
+
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
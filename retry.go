@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// retryConfig controls withRetry/retry.Do's exponential backoff.
+type retryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var defaultRetryConfig = retryConfig{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// backoffDelay returns cfg's exponential backoff delay for the given
+// zero-based attempt, with full jitter applied.
+func backoffDelay(cfg retryConfig, attempt int) time.Duration {
+	delay := cfg.BaseDelay * time.Duration(1<<attempt)
+	if delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// withRetry calls fn up to cfg.MaxAttempts times, backing off
+// exponentially with full jitter between attempts. It returns the last
+// error if every attempt fails, or nil as soon as one succeeds. Used by
+// notifiers, which have no notion of which errors are worth retrying.
+func withRetry(ctx context.Context, cfg retryConfig, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if attempt == cfg.MaxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-time.After(backoffDelay(cfg, attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// Do retries fn for provider errors worth retrying (ErrTransient,
+// ErrRateLimited), failing fast on ErrAuth and ErrBadPayload since no
+// amount of retrying fixes bad credentials or a malformed response.
+// ErrRateLimited's RetryAfter overrides the computed backoff when it's
+// the longer of the two, respecting the upstream's own guidance.
+func Do(ctx context.Context, cfg retryConfig, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		var authErr *ErrAuth
+		var badPayload *ErrBadPayload
+		if errors.As(err, &authErr) || errors.As(err, &badPayload) {
+			return err
+		}
+
+		if attempt == cfg.MaxAttempts-1 {
+			break
+		}
+
+		delay := backoffDelay(cfg, attempt)
+		var rateLimited *ErrRateLimited
+		if errors.As(err, &rateLimited) && rateLimited.RetryAfter > delay {
+			delay = rateLimited.RetryAfter
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package main
+
+import "time"
+
+// Operator is how a Rule's Threshold is compared against the current
+// price.
+type Operator string
+
+const (
+	OpLTE        Operator = "<="
+	OpGTE        Operator = ">="
+	OpCrossDown  Operator = "cross_down"
+	OpPctDrop24h Operator = "pct_drop_24h"
+)
+
+// Rule is one alert definition, typically loaded from rules.yaml. Karat
+// only matters for MetalGold; leave it empty ("24K" is assumed) for
+// MetalSilver and MetalPlatinum.
+type Rule struct {
+	ID           string        `yaml:"id" json:"id"`
+	Metal        Metal         `yaml:"metal" json:"metal"`
+	Karat        string        `yaml:"karat" json:"karat"`
+	Operator     Operator      `yaml:"operator" json:"operator"`
+	Threshold    float64       `yaml:"threshold" json:"threshold"`
+	ResetBuffer  float64       `yaml:"reset_buffer" json:"reset_buffer"`
+	Cooldown     time.Duration `yaml:"cooldown" json:"cooldown"`
+	NotifierTags []string      `yaml:"notifier_tags" json:"notifier_tags"`
+}
+
+// priceFor reads the price Rule r cares about out of a Snapshot.
+func priceFor(snap Snapshot, r Rule) float64 {
+	switch r.Metal {
+	case MetalGold:
+		if r.Karat == "22K" {
+			return snap.Gold22K
+		}
+		return snap.Gold24K
+	case MetalSilver:
+		return snap.Silver
+	case MetalPlatinum:
+		return snap.Platinum
+	default:
+		return 0
+	}
+}
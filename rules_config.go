@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const rulesFile = "rules.yaml"
+
+// defaultRules is used when rules.yaml doesn't exist, preserving the
+// tracker's original single-rule behavior: alert once 22K gold drops to
+// ₹12700/g, and don't re-arm until it recovers past ₹12900/g.
+var defaultRules = []Rule{
+	{
+		ID:          "gold-22k-buy-target",
+		Metal:       MetalGold,
+		Karat:       "22K",
+		Operator:    OpLTE,
+		Threshold:   12700.0,
+		ResetBuffer: 200.0,
+		Cooldown:    24 * time.Hour,
+	},
+}
+
+// loadRules reads rulesFile, if present, else falls back to defaultRules.
+func loadRules() ([]Rule, error) {
+	data, err := os.ReadFile(rulesFile)
+	if os.IsNotExist(err) {
+		return defaultRules, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ScrapeSource is a PriceSource for retail rate-checker sites that publish
+// per-gram INR rates in plain HTML, for users without a GoldAPI key. It
+// only supports INR and the metals the target page actually lists.
+type ScrapeSource struct {
+	// BaseURL points at the retail page to scrape, e.g.
+	// "https://www.example-bullion-rates.com/gold-rate-today".
+	BaseURL string
+	Client  *http.Client
+}
+
+// selector maps a Metal to the CSS selector of the table cell holding its
+// per-gram rate on the target page.
+var scrapeSelectors = map[Metal]string{
+	MetalGold:     "#gold-rate-24k-1g",
+	MetalSilver:   "#silver-rate-1g",
+	MetalPlatinum: "#platinum-rate-1g",
+}
+
+func (s *ScrapeSource) FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error) {
+	if currency != "INR" {
+		return 0, fmt.Errorf("scrape source: unsupported currency %q", currency)
+	}
+
+	selector, ok := scrapeSelectors[metal]
+	if !ok {
+		return 0, fmt.Errorf("scrape source: unsupported metal %q", metal)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.BaseURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return 0, fmt.Errorf("scrape source: status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	text := strings.TrimSpace(doc.Find(selector).First().Text())
+	if text == "" {
+		return 0, fmt.Errorf("scrape source: selector %q not found", selector)
+	}
+
+	text = strings.TrimPrefix(text, "₹")
+	text = strings.ReplaceAll(text, ",", "")
+	price, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, fmt.Errorf("scrape source: parsing %q: %w", text, err)
+	}
+
+	return price, nil
+}
+
+func (s *ScrapeSource) Name() string { return "scrape" }
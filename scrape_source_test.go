@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestScrapeSource_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><span id="gold-rate-24k-1g">₹6,789.50</span></body></html>`))
+	}))
+	defer srv.Close()
+
+	src := &ScrapeSource{Client: srv.Client(), BaseURL: srv.URL}
+	price, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price != 6789.50 {
+		t.Fatalf("expected 6789.50, got %v", price)
+	}
+}
+
+func TestScrapeSource_SelectorNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>no rates here</body></html>`))
+	}))
+	defer srv.Close()
+
+	src := &ScrapeSource{Client: srv.Client(), BaseURL: srv.URL}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err == nil {
+		t.Fatal("expected an error when the selector isn't present")
+	}
+}
+
+func TestScrapeSource_UnsupportedCurrency(t *testing.T) {
+	src := &ScrapeSource{Client: http.DefaultClient, BaseURL: "http://unused"}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "USD")
+	if err == nil {
+		t.Fatal("expected an error for an unsupported currency")
+	}
+}
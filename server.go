@@ -0,0 +1,78 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// metalResponse is the payload served by GET /metal. It mirrors Snapshot
+// but also surfaces yesterday's close so clients can compute day-over-day
+// deltas without keeping their own history.
+type metalResponse struct {
+	Gold24K         float64 `json:"gold_24k"`
+	Gold22K         float64 `json:"gold_22k"`
+	Silver          float64 `json:"silver"`
+	Platinum        float64 `json:"platinum"`
+	GoldDayBefore   float64 `json:"gold_day_before"`
+	SilverDayBefore float64 `json:"silver_day_before"`
+	Time            string  `json:"time"`
+}
+
+// newServer wires the read-only JSON API. state is read fresh on every
+// request so the handler always reflects the latest cached snapshot
+// without re-fetching from the upstream price source.
+func newServer(getState func() State) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metal", basicAuth(func(w http.ResponseWriter, r *http.Request) {
+		s := getState()
+		resp := metalResponse{
+			Gold24K:         s.Latest.Gold24K,
+			Gold22K:         s.Latest.Gold22K,
+			Silver:          s.Latest.Silver,
+			Platinum:        s.Latest.Platinum,
+			GoldDayBefore:   s.DayBefore.Gold24K,
+			SilverDayBefore: s.DayBefore.Silver,
+			Time:            s.Latest.Time.Format("2006-01-02T15:04:05Z07:00"),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+
+	return &http.Server{
+		Addr:    apiAddr,
+		Handler: mux,
+	}
+}
+
+// basicAuth gates a handler behind HTTP Basic Auth using apiUsername /
+// apiPassword. If either is unset, the API is disabled entirely and every
+// request is rejected.
+func basicAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if apiUsername == "" || apiPassword == "" {
+			http.Error(w, "API disabled", http.StatusServiceUnavailable)
+			return
+		}
+
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(apiUsername)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(apiPassword)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="gold-tracker"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func startServer(srv *http.Server) {
+	log.Println("🌐 API listening on", srv.Addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Println("❌ API server error:", err)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPNotifier delivers via a plain SMTP submission, for environments that
+// already have a mail relay and nothing else.
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	To       string
+}
+
+// Notify dials the relay itself (rather than going through
+// smtp.SendMail, which has no dial/IO timeout at all) so the whole
+// exchange is bounded by httpClientTimeout, or ctx's deadline if it's
+// sooner: an unreachable or black-holing mail host fails instead of
+// hanging consumeEvents forever.
+func (s *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.Host, s.Port)
+
+	deadline := time.Now().Add(httpClientTimeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, httpClientTimeout)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(s.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", s.To, msg.Title, msg.Body)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
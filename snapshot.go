@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot is a point-in-time read of all tracked metals, per gram, in INR.
+type Snapshot struct {
+	Gold24K  float64   `json:"gold_24k"`
+	Gold22K  float64   `json:"gold_22k"`
+	Silver   float64   `json:"silver"`
+	Platinum float64   `json:"platinum"`
+	Time     time.Time `json:"time"`
+}
+
+const currency = "INR"
+
+// fetchSnapshot pulls the current per-gram price of every tracked metal
+// from src and derives the 22K gold price from the 24K spot price.
+func fetchSnapshot(ctx context.Context, src PriceSource) (Snapshot, error) {
+	gold24K, err := src.FetchPerGram(ctx, MetalGold, currency)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	silver, err := src.FetchPerGram(ctx, MetalSilver, currency)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	platinum, err := src.FetchPerGram(ctx, MetalPlatinum, currency)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return Snapshot{
+		Gold24K:  gold24K,
+		Gold22K:  convertTo22K(gold24K),
+		Silver:   silver,
+		Platinum: platinum,
+		Time:     time.Now(),
+	}, nil
+}
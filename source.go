@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Metal identifies a tracked precious metal by its commodity ticker.
+type Metal string
+
+const (
+	MetalGold     Metal = "XAU"
+	MetalSilver   Metal = "XAG"
+	MetalPlatinum Metal = "XPT"
+)
+
+// PriceSource fetches the current spot price of a metal, in the given
+// currency, per gram.
+type PriceSource interface {
+	FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error)
+
+	// Name identifies the source for provenance, e.g. in PriceEvent.Source.
+	Name() string
+}
+
+// GoldAPISource is a PriceSource backed by goldapi.io.
+type GoldAPISource struct {
+	APIKey string
+	Client *http.Client
+
+	// BaseURL overrides the goldapi.io origin; tests point this at an
+	// httptest.Server. Leave empty in production.
+	BaseURL string
+
+	// Retry overrides defaultRetryConfig; tests set a low MaxAttempts to
+	// avoid sleeping through the backoff. Leave zero-value in production.
+	Retry retryConfig
+}
+
+type goldAPIResponse struct {
+	PricePerOunce float64 `json:"price"`
+}
+
+const ounceToGram = 31.1035
+
+func (s *GoldAPISource) FetchPerGram(ctx context.Context, metal Metal, currency string) (float64, error) {
+	cfg := s.Retry
+	if cfg.MaxAttempts == 0 {
+		cfg = defaultRetryConfig
+	}
+
+	var price float64
+	err := Do(ctx, cfg, func() error {
+		p, err := s.fetchOnce(ctx, metal, currency)
+		if err != nil {
+			return err
+		}
+		price = p
+		return nil
+	})
+	return price, err
+}
+
+func (s *GoldAPISource) fetchOnce(ctx context.Context, metal Metal, currency string) (float64, error) {
+	base := s.BaseURL
+	if base == "" {
+		base = "https://www.goldapi.io"
+	}
+	url := fmt.Sprintf("%s/api/%s/%s", base, metal, currency)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("x-access-token", s.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return 0, classifyGoldAPIError(resp)
+	}
+
+	var data goldAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, &ErrBadPayload{Err: err}
+	}
+
+	return data.PricePerOunce / ounceToGram, nil
+}
+
+func (s *GoldAPISource) Name() string { return "goldapi" }
+
+func convertTo22K(price24K float64) float64 {
+	const purityFactor = 0.916
+	const gstFactor = 1.03
+	return price24K * purityFactor * gstFactor
+}
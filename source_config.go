@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// buildPriceSource assembles the configured PriceSource from env:
+//
+//	PRICE_SOURCES                 comma-separated list, e.g. "goldapi,scrape"
+//	PRICE_SOURCE_TIMEOUT_SECONDS  per-source attempt timeout (default 10)
+//	SCRAPE_URL                    retail page for ScrapeSource
+//	AGREEMENT_MODE                "true" to require sources to agree instead
+//	                               of falling back to the first success
+//	AGREEMENT_MAX_DISAGREEMENT_PCT  max % spread allowed in agreement mode
+//
+// An unknown name is ignored with a log line rather than failing startup,
+// since a typo here shouldn't take down the whole tracker.
+func buildPriceSource() PriceSource {
+	names := strings.Split(envOr("PRICE_SOURCES", "goldapi"), ",")
+
+	var sources []PriceSource
+	for _, name := range names {
+		src := namedSource(strings.TrimSpace(name))
+		if src != nil {
+			sources = append(sources, src)
+		}
+	}
+	if len(sources) == 0 {
+		sources = []PriceSource{&GoldAPISource{APIKey: goldAPIKey, Client: newHTTPClient()}}
+	}
+
+	if envOr("AGREEMENT_MODE", "false") == "true" {
+		pct, err := strconv.ParseFloat(envOr("AGREEMENT_MAX_DISAGREEMENT_PCT", "2"), 64)
+		if err != nil {
+			pct = 2
+		}
+		return &AgreementSource{Sources: sources, MaxDisagreementPct: pct}
+	}
+
+	timeoutSecs, err := strconv.Atoi(envOr("PRICE_SOURCE_TIMEOUT_SECONDS", "10"))
+	if err != nil {
+		timeoutSecs = 10
+	}
+	return &ChainedSource{Sources: sources, Timeout: time.Duration(timeoutSecs) * time.Second}
+}
+
+func namedSource(name string) PriceSource {
+	switch name {
+	case "goldapi":
+		return &GoldAPISource{APIKey: goldAPIKey, Client: newHTTPClient()}
+	case "scrape":
+		return &ScrapeSource{BaseURL: envOr("SCRAPE_URL", ""), Client: newHTTPClient()}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGoldAPISource_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"price": 3110.35}`))
+	}))
+	defer srv.Close()
+
+	src := &GoldAPISource{Client: srv.Client(), BaseURL: srv.URL}
+	price, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price != 100 {
+		t.Fatalf("expected 100/g, got %v", price)
+	}
+}
+
+func TestGoldAPISource_Auth(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	src := &GoldAPISource{Client: srv.Client(), BaseURL: srv.URL}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+
+	var authErr *ErrAuth
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected ErrAuth, got %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected ErrAuth to fail fast with 1 request, got %d", requests)
+	}
+}
+
+func TestGoldAPISource_RateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	src := &GoldAPISource{Client: srv.Client(), BaseURL: srv.URL, Retry: retryConfig{MaxAttempts: 1}}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestGoldAPISource_UpstreamUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	src := &GoldAPISource{Client: srv.Client(), BaseURL: srv.URL, Retry: retryConfig{MaxAttempts: 1}}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+
+	var unavailable *ErrUpstreamUnavailable
+	if !errors.As(err, &unavailable) {
+		t.Fatalf("expected ErrUpstreamUnavailable, got %v", err)
+	}
+}
+
+func TestGoldAPISource_BadPayload(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`not json`))
+	}))
+	defer srv.Close()
+
+	src := &GoldAPISource{Client: srv.Client(), BaseURL: srv.URL}
+	_, err := src.FetchPerGram(context.Background(), MetalGold, "INR")
+
+	var badPayload *ErrBadPayload
+	if !errors.As(err, &badPayload) {
+		t.Fatalf("expected ErrBadPayload, got %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected ErrBadPayload to fail fast with 1 request, got %d", requests)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// staleNotifyCooldown keeps a stuck upstream from paging on every poll;
+// once we've warned about stale data we wait this long before warning
+// again.
+const staleNotifyCooldown = 6 * time.Hour
+
+// checkStaleness reports whether the last known-good snapshot in state is
+// older than threshold and due a fresh warning, returning the message to
+// send if so. It's the fallback for when every PriceSource attempt has
+// failed outright and there's nothing fresh to evaluate rules against.
+//
+// checkStaleness only updates state.LastStaleNotifiedAt and never calls a
+// Notifier itself, so it's safe to run under a lock; the caller sends the
+// returned message (if any) after releasing it, same as
+// EvaluateRules/SendNotifications.
+func checkStaleness(state *State, now time.Time, threshold time.Duration) *Message {
+	if state.Latest.Time.IsZero() {
+		return nil
+	}
+
+	age := now.Sub(state.Latest.Time)
+	if age < threshold {
+		return nil
+	}
+	if now.Sub(state.LastStaleNotifiedAt) < staleNotifyCooldown {
+		return nil
+	}
+
+	state.LastStaleNotifiedAt = now
+	return &Message{
+		Title: "⚠️ Stale Price Data",
+		Body: fmt.Sprintf(
+			"last price update was %s ago (at %s); upstream sources are failing",
+			age.Round(time.Minute), state.Latest.Time.Format(time.RFC3339),
+		),
+	}
+}
+
+// sendStaleWarning delivers msg via notifier, logging (rather than
+// propagating) a delivery failure, consistent with SendNotifications.
+func sendStaleWarning(ctx context.Context, notifier Notifier, msg *Message) {
+	if msg == nil {
+		return
+	}
+	if err := notifier.Notify(ctx, *msg); err != nil {
+		log.Println("❌ notify error:", err)
+	}
+}
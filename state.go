@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// RuleState is the evaluator's memory for a single Rule: whether it's
+// currently latched (triggered, awaiting the hysteresis reset), the price
+// last seen (for cross_down), and when it last actually notified (for
+// Cooldown).
+type RuleState struct {
+	Triggered      bool      `json:"triggered"`
+	TriggeredAt    time.Time `json:"triggered_at"`
+	LastPrice      float64   `json:"last_price"`
+	LastNotifiedAt time.Time `json:"last_notified_at"`
+}
+
+// State is the tracker's persisted memory across runs: per-rule alert
+// latch state plus enough price history to compute day-over-day deltas.
+type State struct {
+	Rules               map[string]RuleState `json:"rules"`
+	Latest              Snapshot             `json:"latest"`
+	DayBefore           Snapshot             `json:"day_before"`
+	LastStaleNotifiedAt time.Time            `json:"last_stale_notified_at"`
+}
+
+// rollIfNewDay moves Latest into DayBefore when next belongs to a later
+// calendar day than the snapshot currently cached in Latest, then stores
+// next as the new Latest. It's a no-op on the very first run, when Latest
+// is still the zero Snapshot.
+func (s *State) rollIfNewDay(next Snapshot) {
+	if !s.Latest.Time.IsZero() && next.Time.YearDay() != s.Latest.Time.YearDay() {
+		s.DayBefore = s.Latest
+	}
+	s.Latest = next
+}
+
+func loadState() State {
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		return State{Rules: map[string]RuleState{}}
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return State{Rules: map[string]RuleState{}}
+	}
+	if s.Rules == nil {
+		s.Rules = map[string]RuleState{}
+	}
+	return s
+}
+
+func saveState(s State) {
+	data, _ := json.MarshalIndent(s, "", "  ")
+	_ = os.WriteFile(stateFile, data, 0644)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TelegramNotifier delivers via a Telegram bot's sendMessage call.
+type TelegramNotifier struct {
+	BotToken string
+	ChatID   string
+	Client   *http.Client
+}
+
+func (t *TelegramNotifier) Notify(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]string{
+		"chat_id": t.ChatID,
+		"text":    fmt.Sprintf("%s\n%s", msg.Title, msg.Body),
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.BotToken)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("telegram returned %d", resp.StatusCode)
+	}
+
+	return nil
+}